@@ -7,11 +7,20 @@ package oss
 
 import (
 	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/aliyun/alibaba-cloud-sdk-go/services/sts"
 	"github.com/aliyun/aliyun-oss-go-sdk/oss"
 	"github.com/google/uuid"
 
@@ -23,6 +32,40 @@ const (
 	metadataKey = "key"
 
 	maxKeys = 1000
+
+	// presignOperation and its aliases return a signed URL for direct, client-side
+	// GET/PUT access to an object.
+	presignOperation    bindings.OperationKind = "presign"
+	presignPutOperation bindings.OperationKind = "presignPut"
+	presignGetOperation bindings.OperationKind = "presignGet"
+	// postPolicyOperation returns a POST policy document and signature that
+	// browsers can use to upload directly to the bucket.
+	postPolicyOperation bindings.OperationKind = "postPolicy"
+
+	// Multipart operations let a caller drive a large upload part-by-part across
+	// many Invoke calls, which is required when the client itself streams the data.
+	initiateMultipartOperation bindings.OperationKind = "initiateMultipart"
+	uploadPartOperation        bindings.OperationKind = "uploadPart"
+	completeMultipartOperation bindings.OperationKind = "completeMultipart"
+	abortMultipartOperation    bindings.OperationKind = "abortMultipart"
+
+	// copyOperation copies an object, optionally across buckets.
+	copyOperation bindings.OperationKind = "copy"
+
+	defaultPresignExpireSeconds = 900
+
+	// defaultSessionExpiration is the default STS session duration, in seconds, when
+	// sessionExpiration metadata is not provided.
+	defaultSessionExpiration = 3600
+
+	// refreshBeforeExpiration is how long before the STS credentials expire the
+	// background refresher rotates them, so long-lived processes never operate with
+	// expired tokens.
+	refreshBeforeExpiration = 15 * time.Minute
+
+	// minRefreshInterval floors the refresher's sleep so a sessionExpiration at or
+	// below refreshBeforeExpiration can't turn the loop into a tight AssumeRole spin.
+	minRefreshInterval = 30 * time.Second
 )
 
 // AliCloudOSS is a binding for an AliCloud OSS storage bucket.
@@ -30,13 +73,51 @@ type AliCloudOSS struct {
 	metadata *ossMetadata
 	client   *oss.Client
 	logger   logger.Logger
+
+	clientLock sync.RWMutex
+	stopCh     chan struct{}
 }
 
 type ossMetadata struct {
-	Endpoint    string `json:"endpoint"`
-	AccessKeyID string `json:"accessKeyID"`
-	AccessKey   string `json:"accessKey"`
-	Bucket      string `json:"bucket"`
+	Endpoint          string `json:"endpoint"`
+	AccessKeyID       string `json:"accessKeyID"`
+	AccessKey         string `json:"accessKey"`
+	Bucket            string `json:"bucket"`
+	RAMRoleArn        string `json:"ramRoleArn"`
+	RoleSessionName   string `json:"roleSessionName"`
+	Policy            string `json:"policy"`
+	SessionExpiration int    `json:"sessionExpiration"`
+	SecurityToken     string `json:"securityToken"`
+	Region            string `json:"region"`
+	Internal          bool   `json:"internal"`
+	Secure            bool   `json:"secure"`
+	Cname             bool   `json:"cname"`
+	ConnectTimeout    int    `json:"connectTimeout"`
+	ReadTimeout       int    `json:"readTimeout"`
+	Proxy             string `json:"proxy"`
+	UserAgent         string `json:"userAgent"`
+}
+
+// resolvedEndpoint returns the endpoint to dial: the internal VPC endpoint
+// derived from region when internal is set and region is non-empty, or the
+// configured Endpoint otherwise.
+func (m *ossMetadata) resolvedEndpoint() string {
+	if m.Internal && m.Region != "" {
+		scheme := "http"
+		if m.Secure {
+			scheme = "https"
+		}
+
+		return fmt.Sprintf("%s://oss-%s-internal.aliyuncs.com", scheme, m.Region)
+	}
+
+	return m.Endpoint
+}
+
+// usesSTS reports whether the metadata requests RAM role assumption rather than
+// static access keys.
+func (m *ossMetadata) usesSTS() bool {
+	return m.RAMRoleArn != ""
 }
 
 type listPayload struct {
@@ -57,6 +138,16 @@ func (s *AliCloudOSS) Init(metadata bindings.Metadata) error {
 	if err != nil {
 		return err
 	}
+
+	if m.usesSTS() {
+		expiration, err := s.assumeRole(m)
+		if err != nil {
+			return fmt.Errorf("alicloud oss binding error: error assuming role %s: %w", m.RAMRoleArn, err)
+		}
+		s.stopCh = make(chan struct{})
+		go s.refreshCredentials(m, expiration)
+	}
+
 	client, err := s.getClient(m)
 	if err != nil {
 		return err
@@ -67,12 +158,107 @@ func (s *AliCloudOSS) Init(metadata bindings.Metadata) error {
 	return nil
 }
 
+// Close stops the background credential refresher, if one was started.
+func (s *AliCloudOSS) Close() error {
+	if s.stopCh != nil {
+		close(s.stopCh)
+	}
+
+	return nil
+}
+
+// assumeRole calls STS AssumeRole using the configured RAM role and stores the
+// returned temporary credentials on the metadata, returning their expiration time.
+func (s *AliCloudOSS) assumeRole(m *ossMetadata) (time.Time, error) {
+	stsClient, err := sts.NewClientWithAccessKey("", m.AccessKeyID, m.AccessKey)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	sessionExpiration := m.SessionExpiration
+	if sessionExpiration == 0 {
+		sessionExpiration = defaultSessionExpiration
+	}
+
+	request := sts.CreateAssumeRoleRequest()
+	request.Scheme = "https"
+	request.RoleArn = m.RAMRoleArn
+	request.RoleSessionName = m.RoleSessionName
+	request.Policy = m.Policy
+	request.DurationSeconds = strconv.Itoa(sessionExpiration)
+
+	response, err := stsClient.AssumeRole(request)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	expiration, err := time.Parse(time.RFC3339, response.Credentials.Expiration)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error parsing STS credentials expiration: %w", err)
+	}
+
+	s.clientLock.Lock()
+	m.AccessKeyID = response.Credentials.AccessKeyId
+	m.AccessKey = response.Credentials.AccessKeySecret
+	m.SecurityToken = response.Credentials.SecurityToken
+	s.clientLock.Unlock()
+
+	return expiration, nil
+}
+
+// refreshCredentials re-assumes the configured RAM role shortly before the current
+// STS credentials expire and rebuilds the OSS client with the refreshed token, so
+// long-lived Dapr processes never operate with expired credentials.
+func (s *AliCloudOSS) refreshCredentials(m *ossMetadata, expiration time.Time) {
+	for {
+		wait := time.Until(expiration) - refreshBeforeExpiration
+		if wait < minRefreshInterval {
+			wait = minRefreshInterval
+		}
+
+		select {
+		case <-s.stopCh:
+			return
+		case <-time.After(wait):
+		}
+
+		newExpiration, err := s.assumeRole(m)
+		if err != nil {
+			s.logger.Errorf("alicloud oss binding error: error refreshing STS credentials: %v", err)
+			expiration = time.Now().Add(refreshBeforeExpiration)
+
+			continue
+		}
+		expiration = newExpiration
+
+		client, err := s.getClient(m)
+		if err != nil {
+			s.logger.Errorf("alicloud oss binding error: error rebuilding client after STS refresh: %v", err)
+
+			continue
+		}
+
+		s.clientLock.Lock()
+		s.client = client
+		s.clientLock.Unlock()
+	}
+}
+
 func (s *AliCloudOSS) Operations() []bindings.OperationKind {
 	return []bindings.OperationKind{
 		bindings.CreateOperation,
 		bindings.GetOperation,
 		bindings.DeleteOperation,
 		bindings.ListOperation,
+		presignOperation,
+		presignPutOperation,
+		presignGetOperation,
+		postPolicyOperation,
+		initiateMultipartOperation,
+		uploadPartOperation,
+		completeMultipartOperation,
+		abortMultipartOperation,
+		copyOperation,
 	}
 }
 
@@ -86,12 +272,36 @@ func (s *AliCloudOSS) Invoke(req *bindings.InvokeRequest) (*bindings.InvokeRespo
 		return s.delete(req)
 	case bindings.ListOperation:
 		return s.list(req)
+	case presignOperation, presignPutOperation, presignGetOperation:
+		return s.presign(req)
+	case postPolicyOperation:
+		return s.postPolicy(req)
+	case initiateMultipartOperation:
+		return s.initiateMultipart(req)
+	case uploadPartOperation:
+		return s.uploadPart(req)
+	case completeMultipartOperation:
+		return s.completeMultipart(req)
+	case abortMultipartOperation:
+		return s.abortMultipart(req)
+	case copyOperation:
+		return s.copy(req)
 	default:
 		return nil, fmt.Errorf("aliyun oss binding error. unsupported operation %s", req.Operation)
 	}
 }
 
+// create puts req.Data as a single object. It does not support a "multipart"
+// streaming mode: req.Data already arrives fully buffered in memory by the time
+// create runs, so staging it into a temp file and re-uploading via
+// bucket.UploadFile would cost more memory and disk, not less. Callers that need
+// to upload without holding the whole object in memory at once should drive
+// initiateMultipart/uploadPart/completeMultipart themselves instead.
 func (s *AliCloudOSS) create(req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	if req.Metadata["multipart"] == "true" {
+		return nil, fmt.Errorf("alicloud oss binding error: multipart create is no longer supported, use the initiateMultipart/uploadPart/completeMultipart operations instead")
+	}
+
 	key := ""
 	if val, ok := req.Metadata[metadataKey]; ok && val != "" {
 		key = val
@@ -100,25 +310,171 @@ func (s *AliCloudOSS) create(req *bindings.InvokeRequest) (*bindings.InvokeRespo
 		s.logger.Debugf("key not found. generating key %s", key)
 	}
 
-	bucket, err := s.client.Bucket(s.metadata.Bucket)
+	bucket, err := s.bucket()
 	if err != nil {
 		return nil, fmt.Errorf("alicloud oss binding error: error getting bucket failed : %w", err)
 	}
 
+	options, err := createOptions(req.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.withTimeout("create", req.Metadata, func() error {
+		return bucket.PutObject(key, bytes.NewReader(req.Data), options...)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("alicloud oss binding error: error putting object %w", err)
+	}
+
+	return &bindings.InvokeResponse{}, nil
+}
+
+// copy copies an object from sourceKey to destinationKey. When sourceBucket is
+// given, the object is copied from that bucket into the configured bucket via
+// bucket.CopyObjectFrom; otherwise it's copied within the configured bucket via
+// bucket.CopyObject.
+func (s *AliCloudOSS) copy(req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	sourceKey := req.Metadata["sourceKey"]
+	if sourceKey == "" {
+		return nil, fmt.Errorf("alicloud oss binding error: can't read sourceKey value")
+	}
+	destinationKey := req.Metadata["destinationKey"]
+	if destinationKey == "" {
+		return nil, fmt.Errorf("alicloud oss binding error: can't read destinationKey value")
+	}
+
+	options, err := copyOptions(req.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	bucket, err := s.bucket()
+	if err != nil {
+		return nil, fmt.Errorf("alicloud oss binding error: error getting bucket : %w", err)
+	}
+
+	if sourceBucket, ok := req.Metadata["sourceBucket"]; ok && sourceBucket != "" {
+		_, err = bucket.CopyObjectFrom(sourceBucket, sourceKey, destinationKey, options...)
+	} else {
+		_, err = bucket.CopyObject(sourceKey, destinationKey, options...)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("alicloud oss binding error: error copying object : %w", err)
+	}
+
+	return &bindings.InvokeResponse{}, nil
+}
+
+// createMetadataKeys are request metadata keys that createOptions translates into
+// typed oss.Options instead of passing through as object user metadata, plus the
+// keys consumed elsewhere in create/copy that are never object metadata.
+//
+// create has no multipart mode (see the doc comment on create): it rejects
+// multipart=true, and otherwise multipart, partSize, parallel, and checkpointDir
+// are ignored. These keys must still be swallowed here rather than left to createOptions'
+// fallback, or they'd silently turn into arbitrary oss.Meta object tags.
+var createMetadataKeys = map[string]bool{
+	metadataKey:                 true,
+	"multipart":                 true,
+	"partSize":                  true,
+	"parallel":                  true,
+	"checkpointDir":             true,
+	"contentType":               true,
+	"contentEncoding":           true,
+	"contentDisposition":        true,
+	"cacheControl":              true,
+	"contentMD5":                true,
+	"acl":                       true,
+	"storageClass":              true,
+	"serverSideEncryption":      true,
+	"serverSideEncryptionKeyId": true,
+	"sourceKey":                 true,
+	"destinationKey":            true,
+	"sourceBucket":              true,
+	"timeoutMs":                 true,
+}
+
+var aclTypes = map[string]oss.ACLType{
+	"private":           oss.ACLPrivate,
+	"public-read":       oss.ACLPublicRead,
+	"public-read-write": oss.ACLPublicReadWrite,
+	"default":           oss.ACLDefault,
+}
+
+var storageClasses = map[string]oss.StorageClassType{
+	"standard":    oss.StorageStandard,
+	"ia":          oss.StorageIA,
+	"archive":     oss.StorageArchive,
+	"coldarchive": oss.StorageColdArchive,
+}
+
+// createOptions translates a whitelist of well-known metadata keys into typed
+// oss.Options (content headers, ACL, storage class, server-side encryption), and
+// passes everything else through as user metadata via oss.Meta.
+func createOptions(metadata map[string]string) ([]oss.Option, error) {
 	options := []oss.Option{}
-	for k, v := range req.Metadata {
-		if k == "key" {
+
+	if val, ok := metadata["contentType"]; ok && val != "" {
+		options = append(options, oss.ContentType(val))
+	}
+	if val, ok := metadata["contentEncoding"]; ok && val != "" {
+		options = append(options, oss.ContentEncoding(val))
+	}
+	if val, ok := metadata["contentDisposition"]; ok && val != "" {
+		options = append(options, oss.ContentDisposition(val))
+	}
+	if val, ok := metadata["cacheControl"]; ok && val != "" {
+		options = append(options, oss.CacheControl(val))
+	}
+	if val, ok := metadata["contentMD5"]; ok && val != "" {
+		options = append(options, oss.ContentMD5(val))
+	}
+	if val, ok := metadata["acl"]; ok && val != "" {
+		acl, found := aclTypes[val]
+		if !found {
+			return nil, fmt.Errorf("alicloud oss binding error: unknown acl %q", val)
+		}
+		options = append(options, oss.ObjectACL(acl))
+	}
+	if val, ok := metadata["storageClass"]; ok && val != "" {
+		class, found := storageClasses[strings.ToLower(val)]
+		if !found {
+			return nil, fmt.Errorf("alicloud oss binding error: unknown storageClass %q", val)
+		}
+		options = append(options, oss.StorageClass(class))
+	}
+	if val, ok := metadata["serverSideEncryption"]; ok && val != "" {
+		options = append(options, oss.ServerSideEncryption(val))
+		if keyID, ok := metadata["serverSideEncryptionKeyId"]; ok && keyID != "" {
+			options = append(options, oss.ServerSideEncryptionKeyID(keyID))
+		}
+	}
+
+	for k, v := range metadata {
+		if createMetadataKeys[k] {
 			continue
 		}
 		options = append(options, oss.Meta(k, v))
 	}
 
-	err = bucket.PutObject(key, bytes.NewReader(req.Data), options...)
+	return options, nil
+}
+
+// copyOptions builds the oss.Options for a copy call from metadata. OSS only
+// honors content-type/ACL/storage-class/user-metadata overrides on a copy when
+// MetadataDirective(MetaReplace) is also set, so it appends that directive
+// whenever createOptions produced any such overrides.
+func copyOptions(metadata map[string]string) ([]oss.Option, error) {
+	options, err := createOptions(metadata)
 	if err != nil {
-		return nil, fmt.Errorf("alicloud oss binding error: error putting object %w", err)
+		return nil, err
+	}
+	if len(options) > 0 {
+		options = append(options, oss.MetadataDirective(oss.MetaReplace))
 	}
 
-	return &bindings.InvokeResponse{}, nil
+	return options, nil
 }
 
 func (s *AliCloudOSS) get(req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
@@ -129,12 +485,17 @@ func (s *AliCloudOSS) get(req *bindings.InvokeRequest) (*bindings.InvokeResponse
 		return nil, fmt.Errorf("alicloud oss binding error: can't read key value")
 	}
 
-	bucket, err := s.client.Bucket(s.metadata.Bucket)
+	bucket, err := s.bucket()
 	if err != nil {
 		return nil, fmt.Errorf("alicloud oss binding error: error getting bucket : %w", err)
 	}
 
-	body, err := bucket.GetObject(key)
+	var body io.ReadCloser
+	err = s.withTimeout("get", req.Metadata, func() error {
+		var getErr error
+		body, getErr = bucket.GetObject(key)
+		return getErr
+	})
 	if err != nil {
 		serviceErr, ok := err.(oss.ServiceError)
 		if !ok {
@@ -175,12 +536,14 @@ func (s *AliCloudOSS) delete(req *bindings.InvokeRequest) (*bindings.InvokeRespo
 		return nil, fmt.Errorf("alicloud oss binding error: can't read key value")
 	}
 
-	bucket, err := s.client.Bucket(s.metadata.Bucket)
+	bucket, err := s.bucket()
 	if err != nil {
 		return nil, fmt.Errorf("alicloud oss binding error: error getting bucket : %w", err)
 	}
 
-	err = bucket.DeleteObject(key)
+	err = s.withTimeout("delete", req.Metadata, func() error {
+		return bucket.DeleteObject(key)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("alicloud oss binding error: error deleting : %w", err)
 	}
@@ -188,7 +551,7 @@ func (s *AliCloudOSS) delete(req *bindings.InvokeRequest) (*bindings.InvokeRespo
 }
 
 func (s *AliCloudOSS) list(req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
-	bucket, err := s.client.Bucket(s.metadata.Bucket)
+	bucket, err := s.bucket()
 	if err != nil {
 		return nil, fmt.Errorf("alicloud oss binding error: error getting bucket : %w", err)
 	}
@@ -223,6 +586,274 @@ func (s *AliCloudOSS) list(req *bindings.InvokeRequest) (*bindings.InvokeRespons
 	}, nil
 }
 
+// presign returns a signed URL that a client can use to GET or PUT an object
+// directly.
+func (s *AliCloudOSS) presign(req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	var key string
+	if val, ok := req.Metadata[metadataKey]; ok && val != "" {
+		key = val
+	} else {
+		return nil, fmt.Errorf("alicloud oss binding error: can't read key value")
+	}
+
+	method := oss.HTTPGet
+	switch {
+	case req.Operation == presignPutOperation:
+		method = oss.HTTPPut
+	case req.Operation == presignGetOperation:
+		method = oss.HTTPGet
+	case req.Metadata["method"] != "":
+		method = oss.HTTPMethod(strings.ToUpper(req.Metadata["method"]))
+	}
+
+	expireInSec := int64(defaultPresignExpireSeconds)
+	if val, ok := req.Metadata["expireSeconds"]; ok && val != "" {
+		parsed, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("alicloud oss binding error: invalid expireSeconds %q: %w", val, err)
+		}
+		expireInSec = parsed
+	}
+
+	options := []oss.Option{}
+	if val, ok := req.Metadata["contentType"]; ok && val != "" {
+		options = append(options, oss.ContentType(val))
+	}
+	if val, ok := req.Metadata["responseContentDisposition"]; ok && val != "" {
+		options = append(options, oss.ResponseContentDisposition(val))
+	}
+
+	bucket, err := s.bucket()
+	if err != nil {
+		return nil, fmt.Errorf("alicloud oss binding error: error getting bucket : %w", err)
+	}
+
+	signedURL, err := bucket.SignURL(key, method, expireInSec, options...)
+	if err != nil {
+		return nil, fmt.Errorf("alicloud oss binding error: error signing url : %w", err)
+	}
+
+	return &bindings.InvokeResponse{
+		Data: []byte(signedURL),
+		Metadata: map[string]string{
+			"url":       signedURL,
+			"expiresAt": time.Now().Add(time.Duration(expireInSec) * time.Second).Format(time.RFC3339),
+		},
+	}, nil
+}
+
+// postPolicyPayload is the POST policy document that postPolicy encodes and signs,
+// so browsers can upload an object directly to the bucket without proxying through Dapr.
+type postPolicyPayload struct {
+	Expiration string        `json:"expiration"`
+	Conditions []interface{} `json:"conditions"`
+}
+
+func (s *AliCloudOSS) postPolicy(req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	expireInSec := int64(defaultPresignExpireSeconds)
+	if val, ok := req.Metadata["expireSeconds"]; ok && val != "" {
+		parsed, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("alicloud oss binding error: invalid expireSeconds %q: %w", val, err)
+		}
+		expireInSec = parsed
+	}
+
+	keyPrefix := req.Metadata[metadataKey]
+
+	s.clientLock.RLock()
+	accessKeyID := s.metadata.AccessKeyID
+	accessKey := s.metadata.AccessKey
+	securityToken := s.metadata.SecurityToken
+	endpoint := s.metadata.resolvedEndpoint()
+	s.clientLock.RUnlock()
+
+	conditions := []interface{}{
+		map[string]string{"bucket": s.metadata.Bucket},
+	}
+	if keyPrefix != "" {
+		conditions = append(conditions, []interface{}{"starts-with", "$key", keyPrefix})
+	}
+	if val, ok := req.Metadata["contentType"]; ok && val != "" {
+		conditions = append(conditions, []interface{}{"starts-with", "$Content-Type", val})
+	}
+
+	policy := postPolicyPayload{
+		Expiration: time.Now().Add(time.Duration(expireInSec) * time.Second).UTC().Format(time.RFC3339),
+		Conditions: conditions,
+	}
+
+	policyJSON, err := json.Marshal(policy)
+	if err != nil {
+		return nil, fmt.Errorf("alicloud oss binding error: error marshaling post policy : %w", err)
+	}
+	encodedPolicy := base64.StdEncoding.EncodeToString(policyJSON)
+
+	mac := hmac.New(sha1.New, []byte(accessKey))
+	mac.Write([]byte(encodedPolicy))
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	response := map[string]string{
+		"OSSAccessKeyId": accessKeyID,
+		"policy":         encodedPolicy,
+		"signature":      signature,
+		"host":           fmt.Sprintf("https://%s.%s", s.metadata.Bucket, strings.TrimPrefix(strings.TrimPrefix(endpoint, "https://"), "http://")),
+		"key":            keyPrefix,
+	}
+	if securityToken != "" {
+		response["x-oss-security-token"] = securityToken
+	}
+
+	jsonResponse, err := json.Marshal(response)
+	if err != nil {
+		return nil, fmt.Errorf("alicloud oss binding error: error marshaling post policy response : %w", err)
+	}
+
+	return &bindings.InvokeResponse{
+		Data: jsonResponse,
+	}, nil
+}
+
+// uploadPartPayload describes one part submitted to completeMultipart.
+type uploadPartPayload struct {
+	PartNumber int    `json:"partNumber"`
+	ETag       string `json:"etag"`
+}
+
+func multipartUpload(bucket *oss.Bucket, key, uploadID string) oss.InitiateMultipartUploadResult {
+	return oss.InitiateMultipartUploadResult{
+		Bucket:   bucket.BucketName,
+		Key:      key,
+		UploadID: uploadID,
+	}
+}
+
+// initiateMultipart starts a multipart upload and returns its uploadID, so callers
+// can drive the upload part-by-part across many Invoke calls.
+func (s *AliCloudOSS) initiateMultipart(req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	var key string
+	if val, ok := req.Metadata[metadataKey]; ok && val != "" {
+		key = val
+	} else {
+		key = uuid.New().String()
+	}
+
+	bucket, err := s.bucket()
+	if err != nil {
+		return nil, fmt.Errorf("alicloud oss binding error: error getting bucket : %w", err)
+	}
+
+	result, err := bucket.InitiateMultipartUpload(key)
+	if err != nil {
+		return nil, fmt.Errorf("alicloud oss binding error: error initiating multipart upload : %w", err)
+	}
+
+	jsonResponse, err := json.Marshal(map[string]string{"uploadId": result.UploadID, "key": result.Key})
+	if err != nil {
+		return nil, fmt.Errorf("alicloud oss binding error: error marshaling multipart result : %w", err)
+	}
+
+	return &bindings.InvokeResponse{Data: jsonResponse}, nil
+}
+
+// uploadPart uploads req.Data as a single part of an in-progress multipart upload.
+func (s *AliCloudOSS) uploadPart(req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	key, uploadID, err := multipartKeyAndUploadID(req)
+	if err != nil {
+		return nil, err
+	}
+
+	partNumber, err := strconv.Atoi(req.Metadata["partNumber"])
+	if err != nil {
+		return nil, fmt.Errorf("alicloud oss binding error: invalid partNumber %q: %w", req.Metadata["partNumber"], err)
+	}
+
+	bucket, err := s.bucket()
+	if err != nil {
+		return nil, fmt.Errorf("alicloud oss binding error: error getting bucket : %w", err)
+	}
+
+	part, err := bucket.UploadPart(multipartUpload(bucket, key, uploadID), bytes.NewReader(req.Data), int64(len(req.Data)), partNumber)
+	if err != nil {
+		return nil, fmt.Errorf("alicloud oss binding error: error uploading part %d : %w", partNumber, err)
+	}
+
+	jsonResponse, err := json.Marshal(uploadPartPayload{PartNumber: part.PartNumber, ETag: part.ETag})
+	if err != nil {
+		return nil, fmt.Errorf("alicloud oss binding error: error marshaling part result : %w", err)
+	}
+
+	return &bindings.InvokeResponse{Data: jsonResponse}, nil
+}
+
+// completeMultipart finishes an in-progress multipart upload given the list of
+// parts already uploaded via uploadPart.
+func (s *AliCloudOSS) completeMultipart(req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	key, uploadID, err := multipartKeyAndUploadID(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var parts []uploadPartPayload
+	if err = json.Unmarshal(req.Data, &parts); err != nil {
+		return nil, fmt.Errorf("alicloud oss binding error: error unmarshaling parts : %w", err)
+	}
+
+	ossParts := make([]oss.UploadPart, len(parts))
+	for i, p := range parts {
+		ossParts[i] = oss.UploadPart{PartNumber: p.PartNumber, ETag: p.ETag}
+	}
+
+	bucket, err := s.bucket()
+	if err != nil {
+		return nil, fmt.Errorf("alicloud oss binding error: error getting bucket : %w", err)
+	}
+
+	result, err := bucket.CompleteMultipartUpload(multipartUpload(bucket, key, uploadID), ossParts)
+	if err != nil {
+		return nil, fmt.Errorf("alicloud oss binding error: error completing multipart upload : %w", err)
+	}
+
+	jsonResponse, err := json.Marshal(result)
+	if err != nil {
+		return nil, fmt.Errorf("alicloud oss binding error: error marshaling complete result : %w", err)
+	}
+
+	return &bindings.InvokeResponse{Data: jsonResponse}, nil
+}
+
+// abortMultipart cancels an in-progress multipart upload and releases its parts.
+func (s *AliCloudOSS) abortMultipart(req *bindings.InvokeRequest) (*bindings.InvokeResponse, error) {
+	key, uploadID, err := multipartKeyAndUploadID(req)
+	if err != nil {
+		return nil, err
+	}
+
+	bucket, err := s.bucket()
+	if err != nil {
+		return nil, fmt.Errorf("alicloud oss binding error: error getting bucket : %w", err)
+	}
+
+	if err = bucket.AbortMultipartUpload(multipartUpload(bucket, key, uploadID)); err != nil {
+		return nil, fmt.Errorf("alicloud oss binding error: error aborting multipart upload : %w", err)
+	}
+
+	return &bindings.InvokeResponse{}, nil
+}
+
+func multipartKeyAndUploadID(req *bindings.InvokeRequest) (key, uploadID string, err error) {
+	key = req.Metadata[metadataKey]
+	if key == "" {
+		return "", "", fmt.Errorf("alicloud oss binding error: can't read key value")
+	}
+	uploadID = req.Metadata["uploadId"]
+	if uploadID == "" {
+		return "", "", fmt.Errorf("alicloud oss binding error: can't read uploadId value")
+	}
+
+	return key, uploadID, nil
+}
+
 func (s *AliCloudOSS) parseMetadata(metadata bindings.Metadata) (*ossMetadata, error) {
 	b, err := json.Marshal(metadata.Properties)
 	if err != nil {
@@ -239,10 +870,91 @@ func (s *AliCloudOSS) parseMetadata(metadata bindings.Metadata) (*ossMetadata, e
 }
 
 func (s *AliCloudOSS) getClient(metadata *ossMetadata) (*oss.Client, error) {
-	client, err := oss.New(metadata.Endpoint, metadata.AccessKeyID, metadata.AccessKey)
+	options := []oss.ClientOption{}
+	if metadata.SecurityToken != "" {
+		options = append(options, oss.SecurityToken(metadata.SecurityToken))
+	}
+	if metadata.Cname {
+		options = append(options, oss.UseCname(true))
+	}
+	if metadata.ConnectTimeout > 0 || metadata.ReadTimeout > 0 {
+		connectTimeout := metadata.ConnectTimeout
+		if connectTimeout == 0 {
+			connectTimeout = 10
+		}
+		readTimeout := metadata.ReadTimeout
+		if readTimeout == 0 {
+			readTimeout = 20
+		}
+		options = append(options, oss.Timeout(int64(connectTimeout), int64(readTimeout)))
+	}
+	if metadata.Proxy != "" {
+		options = append(options, oss.Proxy(metadata.Proxy))
+	}
+	if metadata.UserAgent != "" {
+		options = append(options, oss.UserAgent(metadata.UserAgent))
+	}
+
+	client, err := oss.New(metadata.resolvedEndpoint(), metadata.AccessKeyID, metadata.AccessKey, options...)
 	if err != nil {
 		return nil, err
 	}
 
 	return client, nil
 }
+
+// withTimeout runs fn on a goroutine and returns as soon as it finishes or the
+// timeout derived from metadata["timeoutMs"] elapses, whichever comes first. With
+// no timeoutMs set, fn runs synchronously and uninstrumented.
+//
+// The underlying OSS SDK calls aren't context-aware, so a timeout here can't
+// actually cancel fn: on timeout, fn keeps running in the background after
+// withTimeout has already returned ctx.Err() to the caller. For label values
+// like "create" or "delete" this means the write or delete can still happen (or
+// fail) after the caller was told the call timed out; withTimeout only logs the
+// eventual outcome, it never un-does it.
+func (s *AliCloudOSS) withTimeout(label string, metadata map[string]string, fn func() error) error {
+	val, ok := metadata["timeoutMs"]
+	if !ok || val == "" {
+		return fn()
+	}
+
+	timeoutMs, err := strconv.Atoi(val)
+	if err != nil {
+		return fmt.Errorf("alicloud oss binding error: invalid timeoutMs %q: %w", val, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeoutMs)*time.Millisecond)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- fn()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		go func() {
+			if err := <-done; err != nil {
+				s.logger.Warnf("alicloud oss binding: %s timed out after %dms, but the underlying call was still running in the background and later failed: %v", label, timeoutMs, err)
+			} else {
+				s.logger.Warnf("alicloud oss binding: %s timed out after %dms, but the underlying call was still running in the background and later completed - it was not actually canceled", label, timeoutMs)
+			}
+		}()
+
+		return ctx.Err()
+	}
+}
+
+// bucket returns a handle to the configured bucket using the most recently
+// refreshed client, so calls made while STS credentials are being rotated in the
+// background still see a usable client.
+func (s *AliCloudOSS) bucket() (*oss.Bucket, error) {
+	s.clientLock.RLock()
+	client := s.client
+	s.clientLock.RUnlock()
+
+	return client.Bucket(s.metadata.Bucket)
+}
@@ -6,7 +6,10 @@
 package oss
 
 import (
+	"context"
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -26,6 +29,24 @@ func TestParseMetadata(t *testing.T) {
 	assert.Equal(t, "test", meta.Bucket)
 }
 
+func TestParseMetadataSTS(t *testing.T) {
+	m := bindings.Metadata{}
+	m.Properties = map[string]string{
+		"endpoint":          "endpoint",
+		"bucket":            "test",
+		"ramRoleArn":        "acs:ram::123456:role/test",
+		"roleSessionName":   "dapr",
+		"sessionExpiration": "900",
+	}
+	aliCloudOSS := AliCloudOSS{}
+	meta, err := aliCloudOSS.parseMetadata(m)
+	assert.Nil(t, err)
+	assert.Equal(t, "acs:ram::123456:role/test", meta.RAMRoleArn)
+	assert.Equal(t, "dapr", meta.RoleSessionName)
+	assert.Equal(t, 900, meta.SessionExpiration)
+	assert.True(t, meta.usesSTS())
+}
+
 func TestOption(t *testing.T) {
 	oss := NewAliCloudOSS(logger.NewLogger("alicloudoss"))
 	oss.metadata = &ossMetadata{}
@@ -36,5 +57,113 @@ func TestOption(t *testing.T) {
 		assert.Error(t, err)
 		_, err = oss.delete(&r)
 		assert.Error(t, err)
+		_, err = oss.presign(&r)
+		assert.Error(t, err)
+		_, err = oss.uploadPart(&r)
+		assert.Error(t, err)
+		_, err = oss.completeMultipart(&r)
+		assert.Error(t, err)
+		_, err = oss.abortMultipart(&r)
+		assert.Error(t, err)
+	})
+
+	t.Run("return error if uploadId is missing", func(t *testing.T) {
+		r := bindings.InvokeRequest{Metadata: map[string]string{metadataKey: "test-key"}}
+		_, err := oss.uploadPart(&r)
+		assert.Error(t, err)
+	})
+
+	t.Run("copy requires source and destination keys", func(t *testing.T) {
+		r := bindings.InvokeRequest{}
+		_, err := oss.copy(&r)
+		assert.Error(t, err)
+
+		r = bindings.InvokeRequest{Metadata: map[string]string{"sourceKey": "a"}}
+		_, err = oss.copy(&r)
+		assert.Error(t, err)
+	})
+
+	t.Run("create rejects multipart=true", func(t *testing.T) {
+		r := bindings.InvokeRequest{Metadata: map[string]string{"multipart": "true"}}
+		_, err := oss.create(&r)
+		assert.Error(t, err)
+	})
+}
+
+func TestCreateOptions(t *testing.T) {
+	options, err := createOptions(map[string]string{
+		"contentType":  "text/plain",
+		"acl":          "public-read",
+		"storageClass": "IA",
+		"customTag":    "customValue",
 	})
+	assert.Nil(t, err)
+	assert.Len(t, options, 4)
+
+	_, err = createOptions(map[string]string{"acl": "not-a-real-acl"})
+	assert.Error(t, err)
+
+	_, err = createOptions(map[string]string{"storageClass": "not-a-real-class"})
+	assert.Error(t, err)
+}
+
+func TestCopyOptions(t *testing.T) {
+	// Any override present means copy must add MetadataDirective(MetaReplace), or
+	// OSS silently keeps the source object's metadata instead of applying it.
+	options, err := copyOptions(map[string]string{"contentType": "text/plain"})
+	assert.Nil(t, err)
+	assert.Len(t, options, 2)
+
+	options, err = copyOptions(map[string]string{"sourceKey": "a", "destinationKey": "b"})
+	assert.Nil(t, err)
+	assert.Empty(t, options)
+}
+
+func TestPostPolicy(t *testing.T) {
+	aliCloudOSS := NewAliCloudOSS(logger.NewLogger("alicloudoss"))
+	aliCloudOSS.metadata = &ossMetadata{
+		Endpoint:    "oss-cn-hangzhou.aliyuncs.com",
+		AccessKeyID: "accessKeyID",
+		AccessKey:   "accessKey",
+		Bucket:      "test",
+	}
+
+	r := bindings.InvokeRequest{Metadata: map[string]string{metadataKey: "uploads/"}}
+	resp, err := aliCloudOSS.postPolicy(&r)
+	assert.Nil(t, err)
+
+	var payload map[string]string
+	err = json.Unmarshal(resp.Data, &payload)
+	assert.Nil(t, err)
+	assert.Equal(t, "accessKeyID", payload["OSSAccessKeyId"])
+	assert.Equal(t, "uploads/", payload["key"])
+	assert.NotEmpty(t, payload["policy"])
+	assert.NotEmpty(t, payload["signature"])
+}
+
+func TestResolvedEndpoint(t *testing.T) {
+	m := &ossMetadata{Endpoint: "oss-cn-hangzhou.aliyuncs.com"}
+	assert.Equal(t, "oss-cn-hangzhou.aliyuncs.com", m.resolvedEndpoint())
+
+	m = &ossMetadata{Region: "cn-hangzhou", Internal: true}
+	assert.Equal(t, "http://oss-cn-hangzhou-internal.aliyuncs.com", m.resolvedEndpoint())
+
+	m = &ossMetadata{Region: "cn-hangzhou", Internal: true, Secure: true}
+	assert.Equal(t, "https://oss-cn-hangzhou-internal.aliyuncs.com", m.resolvedEndpoint())
+}
+
+func TestWithTimeout(t *testing.T) {
+	aliCloudOSS := NewAliCloudOSS(logger.NewLogger("alicloudoss"))
+
+	err := aliCloudOSS.withTimeout("test", map[string]string{}, func() error { return nil })
+	assert.Nil(t, err)
+
+	err = aliCloudOSS.withTimeout("test", map[string]string{"timeoutMs": "50"}, func() error {
+		time.Sleep(200 * time.Millisecond)
+		return nil
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	err = aliCloudOSS.withTimeout("test", map[string]string{"timeoutMs": "not-a-number"}, func() error { return nil })
+	assert.Error(t, err)
 }
@@ -0,0 +1,191 @@
+// ------------------------------------------------------------
+// Copyright (c) Microsoft Corporation and Dapr Contributors.
+// Licensed under the MIT License.
+// ------------------------------------------------------------
+
+package oss
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	aliyunoss "github.com/aliyun/aliyun-oss-go-sdk/oss"
+
+	"github.com/dapr/components-contrib/lock"
+	"github.com/dapr/kit/logger"
+)
+
+// fakeBucket is an in-memory ossBucket used to test lock/unlock semantics
+// without a real OSS endpoint.
+type fakeBucket struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func newFakeBucket() *fakeBucket {
+	return &fakeBucket{objects: map[string][]byte{}}
+}
+
+func (b *fakeBucket) PutObject(objectKey string, data []byte, forbidOverwrite bool) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if forbidOverwrite {
+		if _, exists := b.objects[objectKey]; exists {
+			return aliyunoss.ServiceError{StatusCode: 412, Code: "PreconditionFailed"}
+		}
+	}
+
+	b.objects[objectKey] = data
+
+	return nil
+}
+
+func (b *fakeBucket) GetObject(objectKey string) ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	data, ok := b.objects[objectKey]
+	if !ok {
+		return nil, aliyunoss.ServiceError{StatusCode: 404, Code: "NoSuchKey"}
+	}
+
+	return data, nil
+}
+
+func (b *fakeBucket) DeleteObject(objectKey string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.objects, objectKey)
+
+	return nil
+}
+
+// fakeTableStoreClient is an in-memory tableStoreClient used to test fencing
+// token issuance without a real TableStore instance.
+type fakeTableStoreClient struct {
+	mu     sync.Mutex
+	tokens map[string]int64
+}
+
+func newFakeTableStoreClient() *fakeTableStoreClient {
+	return &fakeTableStoreClient{tokens: map[string]int64{}}
+}
+
+func (f *fakeTableStoreClient) getFencingToken(resourceID string) (int64, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	token, exists := f.tokens[resourceID]
+
+	return token, exists, nil
+}
+
+func (f *fakeTableStoreClient) casFencingToken(resourceID string, expectedExists bool, expectedToken, next int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	current, exists := f.tokens[resourceID]
+	if exists != expectedExists || current != expectedToken {
+		return errConditionFailed
+	}
+
+	f.tokens[resourceID] = next
+
+	return nil
+}
+
+func newTestLock() *AliCloudOSSLock {
+	l := NewAliCloudOSSLock(logger.NewLogger("alicloudoss.lock"))
+	l.metadata = &ossLockMetadata{LockPath: defaultLockPath, LeaseSeconds: defaultLeaseSeconds}
+	l.bucket = newFakeBucket()
+	l.tableStore = newFakeTableStoreClient()
+
+	return l
+}
+
+func TestTryLockAndUnlock(t *testing.T) {
+	l := newTestLock()
+
+	resp, err := l.TryLock(&lock.TryLockRequest{ResourceID: "res1", LockOwner: "owner-a", ExpiryInSeconds: 60})
+	assert.Nil(t, err)
+	assert.True(t, resp.Success)
+
+	// A second owner can't acquire the same lock while it's held.
+	resp, err = l.TryLock(&lock.TryLockRequest{ResourceID: "res1", LockOwner: "owner-b", ExpiryInSeconds: 60})
+	assert.Nil(t, err)
+	assert.False(t, resp.Success)
+
+	// The wrong owner can't unlock it.
+	unlockResp, err := l.Unlock(&lock.UnlockRequest{ResourceID: "res1", LockOwner: "owner-b"})
+	assert.Nil(t, err)
+	assert.Equal(t, lock.LockBelongsToOthers, unlockResp.Status)
+
+	// The owner can unlock it.
+	unlockResp, err = l.Unlock(&lock.UnlockRequest{ResourceID: "res1", LockOwner: "owner-a"})
+	assert.Nil(t, err)
+	assert.Equal(t, lock.Success, unlockResp.Status)
+
+	// Once unlocked, a new owner can acquire it.
+	resp, err = l.TryLock(&lock.TryLockRequest{ResourceID: "res1", LockOwner: "owner-b", ExpiryInSeconds: 60})
+	assert.Nil(t, err)
+	assert.True(t, resp.Success)
+}
+
+func TestUnlockMissingResource(t *testing.T) {
+	l := newTestLock()
+
+	unlockResp, err := l.Unlock(&lock.UnlockRequest{ResourceID: "does-not-exist", LockOwner: "owner-a"})
+	assert.Nil(t, err)
+	assert.Equal(t, lock.LockDoesNotExist, unlockResp.Status)
+}
+
+func TestBreakExpiredLock(t *testing.T) {
+	l := newTestLock()
+
+	_, err := l.TryLock(&lock.TryLockRequest{ResourceID: "res1", LockOwner: "owner-a", ExpiryInSeconds: -1})
+	assert.Nil(t, err)
+
+	resp, err := l.TryLock(&lock.TryLockRequest{ResourceID: "res1", LockOwner: "owner-b", ExpiryInSeconds: 60})
+	assert.Nil(t, err)
+	assert.True(t, resp.Success)
+}
+
+func TestNextFencingTokenIncrements(t *testing.T) {
+	l := newTestLock()
+
+	_, err := l.TryLock(&lock.TryLockRequest{ResourceID: "res1", LockOwner: "owner-a", ExpiryInSeconds: -1})
+	assert.Nil(t, err)
+	_, err = l.TryLock(&lock.TryLockRequest{ResourceID: "res1", LockOwner: "owner-b", ExpiryInSeconds: -1})
+	assert.Nil(t, err)
+
+	token, err := l.nextFencingToken("res1")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), token)
+}
+
+func TestNextFencingTokenPerResource(t *testing.T) {
+	l := newTestLock()
+
+	tokenA1, err := l.nextFencingToken("res-a")
+	assert.Nil(t, err)
+	tokenB1, err := l.nextFencingToken("res-b")
+	assert.Nil(t, err)
+	tokenA2, err := l.nextFencingToken("res-a")
+	assert.Nil(t, err)
+
+	assert.Equal(t, int64(1), tokenA1)
+	assert.Equal(t, int64(1), tokenB1)
+	assert.Equal(t, int64(2), tokenA2)
+}
+
+func TestNextFencingTokenWithoutTableStore(t *testing.T) {
+	l := newTestLock()
+	l.tableStore = nil
+
+	token, err := l.nextFencingToken("res1")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), token)
+}